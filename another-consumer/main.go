@@ -2,7 +2,11 @@ package main
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/xwinata/wstest/mathutils"
+	"github.com/xwinata/wstest/modresolve"
 	stringutils "github.com/xwinata/wstest/stringutils/v2"
 )
 
@@ -10,25 +14,86 @@ func main() {
 	fmt.Println("=== Another External Consumer Application ===")
 	fmt.Println("This consumer adopts the latest versions including breaking changes")
 	fmt.Println()
-	
+
+	reportResolvedVersion("mathutils", "github.com/xwinata/wstest/mathutils", mathutils.VersionString())
+	reportResolvedVersion("stringutils", "github.com/xwinata/wstest/stringutils/v2", stringutils.VersionString())
+
+	mi := mathutils.ModuleInfo()
+	warnIfDeprecated("mathutils", mi.Retracted, mi.RetractionReason, mi.Deprecated, mi.DeprecationMessage, mi.SupersededBy)
+	si := stringutils.ModuleInfo()
+	warnIfDeprecated("stringutils", si.Retracted, si.RetractionReason, si.Deprecated, si.DeprecationMessage, si.SupersededBy)
+	fmt.Println()
+
 	// Use mathutils v1.0.1 (latest with bug fix)
 	fmt.Println("Using mathutils v1.0.1:")
 	result := mathutils.Add(20, 15)
 	fmt.Printf("  mathutils.Add(20, 15) = %d\n", result)
-	fmt.Printf("  mathutils.Version() = %s\n", mathutils.Version())
+	fmt.Printf("  mathutils.VersionString() = %s\n", mathutils.VersionString())
 	fmt.Println()
-	
+
 	// Use stringutils v2.0.0 (with breaking changes)
 	fmt.Println("Using stringutils v2.0.0 (with breaking changes):")
 	greeting1 := stringutils.HelloWorld("Developer", "Welcome")
 	greeting2 := stringutils.HelloWorld("User", "Greetings")
 	fmt.Printf("  stringutils.HelloWorld(\"Developer\", \"Welcome\") = %s\n", greeting1)
 	fmt.Printf("  stringutils.HelloWorld(\"User\", \"Greetings\") = %s\n", greeting2)
-	fmt.Printf("  stringutils.Version() = %s\n", stringutils.Version())
+	fmt.Printf("  stringutils.VersionString() = %s\n", stringutils.VersionString())
 	fmt.Println()
-	
+
 	fmt.Println("This consumer demonstrates:")
 	fmt.Println("  - Using mathutils v1.0.1 (latest bug fix)")
 	fmt.Println("  - Using stringutils v2.0.0 (adopting breaking changes)")
 	fmt.Println("  - Different consumers can make different version choices")
-}
\ No newline at end of file
+}
+
+// reportResolvedVersion prints whether current is the latest available
+// patch release of modulePath's major.minor line, per the Go module
+// proxy, and flags any retracted versions that were skipped.
+func reportResolvedVersion(label, modulePath, current string) {
+	latest, err := modresolve.ResolvePatch(modulePath, current, modresolve.DefaultProxy)
+	if err != nil {
+		fmt.Printf("%s: using %s (could not reach module proxy: %v)\n", label, current, err)
+		return
+	}
+
+	var msg string
+	if latest == current {
+		msg = fmt.Sprintf("%s: using %s (latest patch of %s.x)", label, current, majorMinor(current))
+	} else {
+		msg = fmt.Sprintf("%s: using %s (latest patch of %s.x, upgraded from %s)", label, latest, majorMinor(current), current)
+	}
+
+	if retracted, err := modresolve.RetractedVersions(modulePath, modresolve.DefaultProxy); err == nil && len(retracted) > 0 {
+		var skipped []string
+		for v := range retracted {
+			skipped = append(skipped, v+" retracted")
+		}
+		sort.Strings(skipped)
+		msg = strings.TrimSuffix(msg, ")") + "; " + strings.Join(skipped, ", ") + ")"
+	}
+	fmt.Println(msg)
+}
+
+// warnIfDeprecated prints a warning if the module whose ModuleInfo fields
+// are passed in is retracted or deprecated, suggesting supersededBy as
+// the upgrade path.
+func warnIfDeprecated(label string, retracted bool, retractionReason string, deprecated bool, deprecationMessage, supersededBy string) {
+	if retracted {
+		fmt.Printf("WARNING: %s's in-use version is retracted: %s\n", label, retractionReason)
+	}
+	if deprecated {
+		msg := fmt.Sprintf("WARNING: %s is deprecated: %s", label, deprecationMessage)
+		if supersededBy != "" {
+			msg += fmt.Sprintf(" (use %s instead)", supersededBy)
+		}
+		fmt.Println(msg)
+	}
+}
+
+func majorMinor(version string) string {
+	parts := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 3)
+	if len(parts) < 2 {
+		return version
+	}
+	return "v" + parts[0] + "." + parts[1]
+}
@@ -0,0 +1,393 @@
+// Package apidiff compares the exported API surface of two versions of a
+// Go package and classifies the minimum semantic version bump required to
+// publish the newer version, in the spirit of golang.org/x/exp/cmd/gorelease.
+package apidiff
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// ChangeKind classifies how a single exported identifier changed between
+// the base and target versions of a package.
+type ChangeKind int
+
+const (
+	// Compatible changes cannot break code that already compiles against
+	// the base version.
+	Compatible ChangeKind = iota
+	// Incompatible changes can break existing callers.
+	Incompatible
+	// Added identifiers are new in the target version; since no existing
+	// caller could have referenced them, they are never breaking.
+	Added
+)
+
+// String returns the lower-case name used in reports, e.g. "incompatible".
+func (k ChangeKind) String() string {
+	switch k {
+	case Compatible:
+		return "compatible"
+	case Incompatible:
+		return "incompatible"
+	case Added:
+		return "added"
+	default:
+		return "unknown"
+	}
+}
+
+// Change describes a single difference between the exported API of the
+// base and target package versions.
+type Change struct {
+	Symbol  string     `json:"symbol"` // e.g. "HelloWorld" or "Config.Name"
+	Kind    ChangeKind `json:"kind"`
+	Message string     `json:"message"` // human-readable explanation
+}
+
+// Bump is the minimum semantic version bump required to publish a target
+// version given its Changes: major for any Incompatible change, minor if
+// there is no Incompatible change but at least one Added, patch otherwise.
+type Bump int
+
+const (
+	BumpPatch Bump = iota
+	BumpMinor
+	BumpMajor
+)
+
+// String returns the bump name as used in reports, e.g. "major".
+func (b Bump) String() string {
+	switch b {
+	case BumpMajor:
+		return "major"
+	case BumpMinor:
+		return "minor"
+	default:
+		return "patch"
+	}
+}
+
+// Report is the result of diffing the exported API of two versions of a
+// package.
+type Report struct {
+	BasePkg   string   `json:"basePkg"`
+	TargetPkg string   `json:"targetPkg"`
+	Changes   []Change `json:"changes"`
+	Bump      Bump     `json:"bump"`
+}
+
+// Load loads the Go package at importPath, rooted at dir, with full type
+// information. dir is typically the module root containing importPath's
+// go.mod.
+func Load(dir, importPath string) (*packages.Package, error) {
+	cfg := &packages.Config{
+		Dir: dir,
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedTypesInfo |
+			packages.NeedSyntax | packages.NeedDeps | packages.NeedImports,
+	}
+	pkgs, err := packages.Load(cfg, importPath)
+	if err != nil {
+		return nil, fmt.Errorf("apidiff: loading %s: %w", importPath, err)
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("apidiff: expected 1 package for %s, got %d", importPath, len(pkgs))
+	}
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		return nil, fmt.Errorf("apidiff: %s: %v", importPath, pkg.Errors[0])
+	}
+	return pkg, nil
+}
+
+// Diff compares the exported API of base against target and returns a
+// Report enumerating every change and the minimum version bump required
+// to publish target.
+func Diff(base, target *packages.Package) Report {
+	r := Report{BasePkg: base.PkgPath, TargetPkg: target.PkgPath}
+
+	baseScope := base.Types.Scope()
+	targetScope := target.Types.Scope()
+
+	for _, name := range exportedNames(baseScope) {
+		baseObj := baseScope.Lookup(name)
+		targetObj := targetScope.Lookup(name)
+		if targetObj == nil {
+			r.Changes = append(r.Changes, Change{
+				Symbol:  name,
+				Kind:    Incompatible,
+				Message: fmt.Sprintf("%s: removed", name),
+			})
+			continue
+		}
+		r.Changes = append(r.Changes, diffObject(name, baseObj, targetObj)...)
+	}
+	for _, name := range exportedNames(targetScope) {
+		if baseScope.Lookup(name) == nil {
+			r.Changes = append(r.Changes, Change{
+				Symbol:  name,
+				Kind:    Added,
+				Message: fmt.Sprintf("%s: added", name),
+			})
+		}
+	}
+
+	sort.Slice(r.Changes, func(i, j int) bool { return r.Changes[i].Symbol < r.Changes[j].Symbol })
+	r.Bump = RequiredBump(r.Changes)
+	return r
+}
+
+// RequiredBump returns the minimum semver bump implied by changes: major
+// if any change is Incompatible, minor if there is no Incompatible change
+// but at least one Added, patch otherwise (including no changes at all).
+func RequiredBump(changes []Change) Bump {
+	bump := BumpPatch
+	for _, c := range changes {
+		switch c.Kind {
+		case Incompatible:
+			return BumpMajor
+		case Added:
+			if bump < BumpMinor {
+				bump = BumpMinor
+			}
+		}
+	}
+	return bump
+}
+
+func exportedNames(scope *types.Scope) []string {
+	var names []string
+	for _, name := range scope.Names() {
+		if ast.IsExported(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// diffObject compares a single top-level identifier that exists in both
+// versions and returns zero or more Changes describing how it moved.
+func diffObject(name string, base, target types.Object) []Change {
+	switch base := base.(type) {
+	case *types.Func:
+		targetFunc, ok := target.(*types.Func)
+		if !ok {
+			return []Change{{Symbol: name, Kind: Incompatible, Message: fmt.Sprintf("%s: changed from func to %s", name, kindName(target))}}
+		}
+		return diffSignature(name, base.Type().(*types.Signature), targetFunc.Type().(*types.Signature))
+
+	case *types.TypeName:
+		targetType, ok := target.(*types.TypeName)
+		if !ok {
+			return []Change{{Symbol: name, Kind: Incompatible, Message: fmt.Sprintf("%s: changed from type to %s", name, kindName(target))}}
+		}
+		changes := diffType(name, base.Type(), targetType.Type())
+		if _, isInterface := base.Type().Underlying().(*types.Interface); !isInterface {
+			changes = append(changes, diffMethodSet(name, base.Type(), targetType.Type())...)
+		}
+		return changes
+
+	case *types.Const, *types.Var:
+		if !types.Identical(base.Type(), target.Type()) {
+			return []Change{{Symbol: name, Kind: Incompatible, Message: fmt.Sprintf("%s: type changed from %s to %s", name, base.Type(), target.Type())}}
+		}
+	}
+	return nil
+}
+
+func diffSignature(name string, base, target *types.Signature) []Change {
+	if base.Variadic() != target.Variadic() {
+		return []Change{{Symbol: name, Kind: Incompatible, Message: fmt.Sprintf("%s: variadic-ness changed", name)}}
+	}
+	if base.Params().Len() != target.Params().Len() {
+		return []Change{{Symbol: name, Kind: Incompatible, Message: fmt.Sprintf("%s: parameter count changed", name)}}
+	}
+	if base.Results().Len() != target.Results().Len() {
+		return []Change{{Symbol: name, Kind: Incompatible, Message: fmt.Sprintf("%s: result count changed", name)}}
+	}
+	for i := 0; i < base.Params().Len(); i++ {
+		if !types.Identical(base.Params().At(i).Type(), target.Params().At(i).Type()) {
+			return []Change{{Symbol: name, Kind: Incompatible, Message: fmt.Sprintf("%s: parameter %d type changed", name, i)}}
+		}
+	}
+	for i := 0; i < base.Results().Len(); i++ {
+		if !types.Identical(base.Results().At(i).Type(), target.Results().At(i).Type()) {
+			return []Change{{Symbol: name, Kind: Incompatible, Message: fmt.Sprintf("%s: result %d type changed", name, i)}}
+		}
+	}
+	return nil
+}
+
+func diffType(name string, base, target types.Type) []Change {
+	switch base := base.Underlying().(type) {
+	case *types.Struct:
+		target, ok := target.Underlying().(*types.Struct)
+		if !ok {
+			return []Change{{Symbol: name, Kind: Incompatible, Message: fmt.Sprintf("%s: no longer a struct", name)}}
+		}
+		return diffStruct(name, base, target)
+
+	case *types.Interface:
+		target, ok := target.Underlying().(*types.Interface)
+		if !ok {
+			return []Change{{Symbol: name, Kind: Incompatible, Message: fmt.Sprintf("%s: no longer an interface", name)}}
+		}
+		return diffInterface(name, base, target)
+	}
+	return nil
+}
+
+// diffStruct reports field removals and type changes as Incompatible
+// always. A field addition is Compatible only if the struct already has
+// an unexported field: that forces external packages to use keyed
+// composite literals, so unkeyed literals elsewhere in the program cannot
+// exist to break. Otherwise an added field is Incompatible, since it can
+// break unkeyed composite literals in other packages.
+func diffStruct(name string, base, target *types.Struct) []Change {
+	var changes []Change
+	baseFields := structFields(base)
+	targetFields := structFields(target)
+	hasUnexported := structHasUnexportedField(base)
+
+	for fname, bf := range baseFields {
+		tf, ok := targetFields[fname]
+		if !ok {
+			changes = append(changes, Change{Symbol: name + "." + fname, Kind: Incompatible, Message: fmt.Sprintf("%s.%s: removed", name, fname)})
+			continue
+		}
+		if !types.Identical(bf.Type(), tf.Type()) {
+			changes = append(changes, Change{Symbol: name + "." + fname, Kind: Incompatible, Message: fmt.Sprintf("%s.%s: type changed", name, fname)})
+		}
+	}
+	for fname := range targetFields {
+		if _, ok := baseFields[fname]; ok {
+			continue
+		}
+		if hasUnexported {
+			changes = append(changes, Change{Symbol: name + "." + fname, Kind: Compatible, Message: fmt.Sprintf("%s.%s: added (struct already has an unexported field, so unkeyed literals are impossible)", name, fname)})
+		} else {
+			changes = append(changes, Change{Symbol: name + "." + fname, Kind: Incompatible, Message: fmt.Sprintf("%s.%s: added field may break unkeyed composite literals", name, fname)})
+		}
+	}
+	return changes
+}
+
+// diffMethodSet compares the exported method sets of two named types
+// (e.g. a struct with declared methods), using the pointer method set so
+// both value- and pointer-receiver methods are included, matching what an
+// external package could actually call. A removed or resignatured method
+// is Incompatible; an added one is Added, since no existing caller could
+// have referenced it.
+func diffMethodSet(name string, base, target types.Type) []Change {
+	baseNamed, ok := base.(*types.Named)
+	if !ok {
+		return nil
+	}
+	targetNamed, ok := target.(*types.Named)
+	if !ok {
+		return nil
+	}
+
+	var changes []Change
+	baseMethods := exportedMethodSet(baseNamed)
+	targetMethods := exportedMethodSet(targetNamed)
+
+	for mname, bsig := range baseMethods {
+		tsig, ok := targetMethods[mname]
+		if !ok {
+			changes = append(changes, Change{Symbol: name + "." + mname, Kind: Incompatible, Message: fmt.Sprintf("%s.%s: method removed", name, mname)})
+			continue
+		}
+		if !types.Identical(bsig, tsig) {
+			changes = append(changes, Change{Symbol: name + "." + mname, Kind: Incompatible, Message: fmt.Sprintf("%s.%s: method signature changed", name, mname)})
+		}
+	}
+	for mname := range targetMethods {
+		if _, ok := baseMethods[mname]; !ok {
+			changes = append(changes, Change{Symbol: name + "." + mname, Kind: Added, Message: fmt.Sprintf("%s.%s: method added", name, mname)})
+		}
+	}
+	return changes
+}
+
+func exportedMethodSet(named *types.Named) map[string]*types.Signature {
+	methods := make(map[string]*types.Signature)
+	set := types.NewMethodSet(types.NewPointer(named))
+	for i := 0; i < set.Len(); i++ {
+		fn := set.At(i).Obj().(*types.Func)
+		if fn.Exported() {
+			methods[fn.Name()] = fn.Type().(*types.Signature)
+		}
+	}
+	return methods
+}
+
+func structFields(s *types.Struct) map[string]*types.Var {
+	fields := make(map[string]*types.Var)
+	for i := 0; i < s.NumFields(); i++ {
+		f := s.Field(i)
+		if f.Exported() {
+			fields[f.Name()] = f
+		}
+	}
+	return fields
+}
+
+func structHasUnexportedField(s *types.Struct) bool {
+	for i := 0; i < s.NumFields(); i++ {
+		if !s.Field(i).Exported() {
+			return true
+		}
+	}
+	return false
+}
+
+// diffInterface reports an added method as Incompatible, since it widens
+// the obligation on existing implementers, and a removed method as
+// Compatible, since it only narrows that obligation. A changed signature
+// is Incompatible.
+func diffInterface(name string, base, target *types.Interface) []Change {
+	var changes []Change
+	baseMethods := interfaceMethods(base)
+	targetMethods := interfaceMethods(target)
+
+	for mname, bm := range baseMethods {
+		tm, ok := targetMethods[mname]
+		if !ok {
+			changes = append(changes, Change{Symbol: name + "." + mname, Kind: Compatible, Message: fmt.Sprintf("%s.%s: method removed, narrowing the interface", name, mname)})
+			continue
+		}
+		if !types.Identical(bm, tm) {
+			changes = append(changes, Change{Symbol: name + "." + mname, Kind: Incompatible, Message: fmt.Sprintf("%s.%s: method signature changed", name, mname)})
+		}
+	}
+	for mname := range targetMethods {
+		if _, ok := baseMethods[mname]; !ok {
+			changes = append(changes, Change{Symbol: name + "." + mname, Kind: Incompatible, Message: fmt.Sprintf("%s.%s: method added, widening the interface", name, mname)})
+		}
+	}
+	return changes
+}
+
+func interfaceMethods(iface *types.Interface) map[string]*types.Signature {
+	methods := make(map[string]*types.Signature)
+	for i := 0; i < iface.NumMethods(); i++ {
+		m := iface.Method(i)
+		if m.Exported() {
+			methods[m.Name()] = m.Type().(*types.Signature)
+		}
+	}
+	return methods
+}
+
+func kindName(obj types.Object) string {
+	if obj == nil {
+		return "nothing"
+	}
+	return fmt.Sprintf("%T", obj)
+}
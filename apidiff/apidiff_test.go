@@ -0,0 +1,36 @@
+package apidiff_test
+
+import (
+	"testing"
+
+	"github.com/xwinata/wstest/apidiff"
+)
+
+// TestStringutilsV1ToV2RequiresMajorBump checks that diffing stringutils
+// v1 against stringutils/v2 in this repo is classified as requiring a
+// major version bump, since HelloWorld gained a required parameter.
+func TestStringutilsV1ToV2RequiresMajorBump(t *testing.T) {
+	base, err := apidiff.Load("..", "github.com/xwinata/wstest/stringutils")
+	if err != nil {
+		t.Fatalf("loading base package: %v", err)
+	}
+	target, err := apidiff.Load("..", "github.com/xwinata/wstest/stringutils/v2")
+	if err != nil {
+		t.Fatalf("loading target package: %v", err)
+	}
+
+	report := apidiff.Diff(base, target)
+	if report.Bump != apidiff.BumpMajor {
+		t.Fatalf("Bump = %s, want %s", report.Bump, apidiff.BumpMajor)
+	}
+
+	var found bool
+	for _, c := range report.Changes {
+		if c.Symbol == "HelloWorld" && c.Kind == apidiff.Incompatible {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected an incompatible change for HelloWorld, got %+v", report.Changes)
+	}
+}
@@ -0,0 +1,31 @@
+package apidiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// WriteText writes r as human-readable text to w, in the style of
+// gorelease: one line per change, followed by the verdict.
+func WriteText(w io.Writer, r Report) error {
+	if len(r.Changes) == 0 {
+		_, err := fmt.Fprintf(w, "%s -> %s: no exported API changes, patch version bump required.\n", r.BasePkg, r.TargetPkg)
+		return err
+	}
+
+	for _, c := range r.Changes {
+		if _, err := fmt.Fprintf(w, "%s change: %s\n", c.Kind, c.Message); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%s version bump required.\n", r.Bump)
+	return err
+}
+
+// WriteJSON writes r to w as indented JSON.
+func WriteJSON(w io.Writer, r Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
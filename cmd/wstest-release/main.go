@@ -0,0 +1,70 @@
+// Command wstest-release reports the minimum semantic version bump
+// required between two versions of a package in this repo, in the style
+// of golang.org/x/exp/cmd/gorelease.
+//
+// Usage:
+//
+//	wstest-release -base <dir>:<importPath> -target <dir>:<importPath> [-json]
+//
+// Example:
+//
+//	wstest-release -base .:github.com/xwinata/wstest/stringutils \
+//	    -target .:github.com/xwinata/wstest/stringutils/v2
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/xwinata/wstest/apidiff"
+)
+
+func main() {
+	var base, target string
+	var jsonOut bool
+	flag.StringVar(&base, "base", "", "base package, as <dir>:<importPath>")
+	flag.StringVar(&target, "target", "", "target package, as <dir>:<importPath>")
+	flag.BoolVar(&jsonOut, "json", false, "print the report as JSON")
+	flag.Parse()
+
+	if err := run(base, target, jsonOut); err != nil {
+		fmt.Fprintln(os.Stderr, "wstest-release:", err)
+		os.Exit(1)
+	}
+}
+
+func run(base, target string, jsonOut bool) error {
+	baseDir, baseImport, err := splitSpec(base)
+	if err != nil {
+		return fmt.Errorf("-base: %w", err)
+	}
+	targetDir, targetImport, err := splitSpec(target)
+	if err != nil {
+		return fmt.Errorf("-target: %w", err)
+	}
+
+	basePkg, err := apidiff.Load(baseDir, baseImport)
+	if err != nil {
+		return err
+	}
+	targetPkg, err := apidiff.Load(targetDir, targetImport)
+	if err != nil {
+		return err
+	}
+
+	report := apidiff.Diff(basePkg, targetPkg)
+	if jsonOut {
+		return apidiff.WriteJSON(os.Stdout, report)
+	}
+	return apidiff.WriteText(os.Stdout, report)
+}
+
+func splitSpec(spec string) (dir, importPath string, err error) {
+	i := strings.Index(spec, ":")
+	if spec == "" || i < 0 {
+		return "", "", fmt.Errorf("expected <dir>:<importPath>, got %q", spec)
+	}
+	return spec[:i], spec[i+1:], nil
+}
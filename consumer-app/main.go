@@ -2,29 +2,94 @@ package main
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/xwinata/wstest/mathutils"
+	"github.com/xwinata/wstest/modresolve"
 	"github.com/xwinata/wstest/stringutils"
 )
 
 func main() {
 	fmt.Println("=== External Consumer Application ===")
 	fmt.Println()
-	
+
+	reportResolvedVersion("mathutils", "github.com/xwinata/wstest/mathutils", mathutils.VersionString())
+	reportResolvedVersion("stringutils", "github.com/xwinata/wstest/stringutils", stringutils.VersionString())
+
+	mi := mathutils.ModuleInfo()
+	warnIfDeprecated("mathutils", mi.Retracted, mi.RetractionReason, mi.Deprecated, mi.DeprecationMessage, mi.SupersededBy)
+	si := stringutils.ModuleInfo()
+	warnIfDeprecated("stringutils", si.Retracted, si.RetractionReason, si.Deprecated, si.DeprecationMessage, si.SupersededBy)
+	fmt.Println()
+
 	// Use mathutils functions
 	fmt.Println("Using mathutils:")
 	result := mathutils.Add(10, 5)
 	fmt.Printf("  mathutils.Add(10, 5) = %d\n", result)
-	fmt.Printf("  mathutils.Version() = %s\n", mathutils.Version())
+	fmt.Printf("  mathutils.VersionString() = %s\n", mathutils.VersionString())
 	fmt.Println()
-	
+
 	// Use stringutils functions
 	fmt.Println("Using stringutils:")
 	greeting := stringutils.HelloWorld("External User")
 	fmt.Printf("  stringutils.HelloWorld(\"External User\") = %s\n", greeting)
-	fmt.Printf("  stringutils.Version() = %s\n", stringutils.Version())
+	fmt.Printf("  stringutils.VersionString() = %s\n", stringutils.VersionString())
 	fmt.Println()
-	
+
 	fmt.Println("This consumer uses:")
 	fmt.Println("  - mathutils v1.0.1 (upgraded for bug fix)")
 	fmt.Println("  - stringutils v1.0.0 (no changes needed)")
-}
\ No newline at end of file
+}
+
+// reportResolvedVersion prints whether current is the latest available
+// patch release of modulePath's major.minor line, per the Go module
+// proxy, and flags any retracted versions that were skipped.
+func reportResolvedVersion(label, modulePath, current string) {
+	latest, err := modresolve.ResolvePatch(modulePath, current, modresolve.DefaultProxy)
+	if err != nil {
+		fmt.Printf("%s: using %s (could not reach module proxy: %v)\n", label, current, err)
+		return
+	}
+
+	var msg string
+	if latest == current {
+		msg = fmt.Sprintf("%s: using %s (latest patch of %s.x)", label, current, majorMinor(current))
+	} else {
+		msg = fmt.Sprintf("%s: using %s (latest patch of %s.x, upgraded from %s)", label, latest, majorMinor(current), current)
+	}
+
+	if retracted, err := modresolve.RetractedVersions(modulePath, modresolve.DefaultProxy); err == nil && len(retracted) > 0 {
+		var skipped []string
+		for v := range retracted {
+			skipped = append(skipped, v+" retracted")
+		}
+		sort.Strings(skipped)
+		msg = strings.TrimSuffix(msg, ")") + "; " + strings.Join(skipped, ", ") + ")"
+	}
+	fmt.Println(msg)
+}
+
+// warnIfDeprecated prints a warning if the module whose ModuleInfo fields
+// are passed in is retracted or deprecated, suggesting supersededBy as
+// the upgrade path.
+func warnIfDeprecated(label string, retracted bool, retractionReason string, deprecated bool, deprecationMessage, supersededBy string) {
+	if retracted {
+		fmt.Printf("WARNING: %s's in-use version is retracted: %s\n", label, retractionReason)
+	}
+	if deprecated {
+		msg := fmt.Sprintf("WARNING: %s is deprecated: %s", label, deprecationMessage)
+		if supersededBy != "" {
+			msg += fmt.Sprintf(" (use %s instead)", supersededBy)
+		}
+		fmt.Println(msg)
+	}
+}
+
+func majorMinor(version string) string {
+	parts := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 3)
+	if len(parts) < 2 {
+		return version
+	}
+	return "v" + parts[0] + "." + parts[1]
+}
@@ -9,8 +9,3 @@ func Add(a, b int) int {
 func Multiply(a, b int) int {
 	return a * b
 }
-
-// Version returns the module version
-func Version() string {
-	return "v1.0.1"
-}
\ No newline at end of file
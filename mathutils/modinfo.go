@@ -0,0 +1,20 @@
+package mathutils
+
+// ModInfo is a module's retraction and deprecation metadata, as published
+// in its go.mod, so callers can get the same signal the go command uses
+// without parsing go.mod themselves.
+type ModInfo struct {
+	Path               string
+	Version            string
+	Retracted          bool
+	RetractionReason   string
+	Deprecated         bool
+	DeprecationMessage string
+	SupersededBy       string
+}
+
+// ModuleInfo returns this package's retraction and deprecation metadata,
+// generated from go.mod by cmd/wstest-modinfogen.
+func ModuleInfo() ModInfo {
+	return moduleInfo
+}
@@ -0,0 +1,167 @@
+package mathutils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// currentVersion is the version of this package.
+var currentVersion = Version{Major: 1, Minor: 0, Patch: 1}
+
+// Version is a parsed SemVer 2.0.0 (https://semver.org) version number.
+// It lets callers compare mathutils releases programmatically instead of
+// string-comparing the output of VersionString().
+type Version struct {
+	Major, Minor, Patch int
+	Prerelease          string
+	Build               string
+}
+
+// ParseVersion parses a SemVer 2.0.0 string such as "v1.0.1" or
+// "1.2.3-rc.1+build.5". A leading "v" is optional.
+func ParseVersion(s string) (Version, error) {
+	orig := s
+	s = strings.TrimPrefix(s, "v")
+
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		build := s[i+1:]
+		s = s[:i]
+		v, err := parseVersionCore(s, orig)
+		if err != nil {
+			return Version{}, err
+		}
+		v.Build = build
+		return v, nil
+	}
+	return parseVersionCore(s, orig)
+}
+
+func parseVersionCore(s, orig string) (Version, error) {
+	var v Version
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		v.Prerelease = s[i+1:]
+		s = s[:i]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("mathutils: invalid version %q", orig)
+	}
+	nums := make([]int, 3)
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("mathutils: invalid version %q", orig)
+		}
+		nums[i] = n
+	}
+	v.Major, v.Minor, v.Patch = nums[0], nums[1], nums[2]
+	return v, nil
+}
+
+// String formats v as "vMAJOR.MINOR.PATCH[-PRERELEASE][+BUILD]".
+func (v Version) String() string {
+	s := fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// IsPrerelease reports whether v has a prerelease component.
+func (v Version) IsPrerelease() bool {
+	return v.Prerelease != ""
+}
+
+// Compare returns -1, 0, or +1 depending on whether v is less than, equal
+// to, or greater than other. Build metadata is ignored, as required by
+// SemVer 2.0.0.
+func (v Version) Compare(other Version) int {
+	if d := compareInt(v.Major, other.Major); d != 0 {
+		return d
+	}
+	if d := compareInt(v.Minor, other.Minor); d != 0 {
+		return d
+	}
+	if d := compareInt(v.Patch, other.Patch); d != 0 {
+		return d
+	}
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+// LessThan reports whether v has lower precedence than other.
+func (v Version) LessThan(other Version) bool {
+	return v.Compare(other) < 0
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// comparePrerelease compares two dot-separated prerelease strings per the
+// SemVer 2.0.0 precedence rules: a version without a prerelease outranks
+// one with a prerelease, and identifiers are compared left to right,
+// numerically if both are numeric and lexically otherwise.
+func comparePrerelease(a, b string) int {
+	if a == "" && b == "" {
+		return 0
+	}
+	if a == "" {
+		return 1
+	}
+	if b == "" {
+		return -1
+	}
+
+	aIDs := strings.Split(a, ".")
+	bIDs := strings.Split(b, ".")
+	for i := 0; i < len(aIDs) && i < len(bIDs); i++ {
+		if d := compareIdentifier(aIDs[i], bIDs[i]); d != 0 {
+			return d
+		}
+	}
+	return compareInt(len(aIDs), len(bIDs))
+}
+
+func compareIdentifier(a, b string) int {
+	aNum, aIsNum := toUint(a)
+	bNum, bIsNum := toUint(b)
+	switch {
+	case aIsNum && bIsNum:
+		return compareInt(int(aNum), int(bNum))
+	case aIsNum && !bIsNum:
+		return -1
+	case !aIsNum && bIsNum:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func toUint(s string) (uint64, bool) {
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// VersionString returns the module version, e.g. "v1.0.1".
+//
+// It is a thin wrapper kept for callers that predate the structured
+// Version type; use ParseVersion(VersionString()) and Version's methods
+// to compare versions programmatically.
+func VersionString() string {
+	return currentVersion.String()
+}
@@ -0,0 +1,79 @@
+package mathutils_test
+
+import (
+	"testing"
+
+	"github.com/xwinata/wstest/mathutils"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in   string
+		want mathutils.Version
+	}{
+		{"v1.0.1", mathutils.Version{Major: 1, Minor: 0, Patch: 1}},
+		{"1.2.3", mathutils.Version{Major: 1, Minor: 2, Patch: 3}},
+		{"v1.2.3-rc.1", mathutils.Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"}},
+		{"v1.2.3+build.5", mathutils.Version{Major: 1, Minor: 2, Patch: 3, Build: "build.5"}},
+		{"v1.2.3-rc.1+build.5", mathutils.Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1", Build: "build.5"}},
+	}
+	for _, tt := range tests {
+		got, err := mathutils.ParseVersion(tt.in)
+		if err != nil {
+			t.Errorf("ParseVersion(%q) returned error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseVersion(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseVersionErrors(t *testing.T) {
+	for _, in := range []string{"garbage", "1.2", "1.2.3.4", "1.2.x"} {
+		if _, err := mathutils.ParseVersion(in); err == nil {
+			t.Errorf("ParseVersion(%q) error = nil, want error", in)
+		}
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	mustParse := func(s string) mathutils.Version {
+		v, err := mathutils.ParseVersion(s)
+		if err != nil {
+			t.Fatalf("ParseVersion(%q): %v", s, err)
+		}
+		return v
+	}
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		// Numeric precedence.
+		{"v1.0.0", "v2.0.0", -1},
+		{"v2.0.0", "v1.0.0", 1},
+		{"v1.2.3", "v1.2.3", 0},
+
+		// A version without a prerelease outranks one with a prerelease.
+		{"v1.0.0", "v1.0.0-alpha", 1},
+		{"v1.0.0-alpha", "v1.0.0", -1},
+
+		// Numeric identifiers compare numerically, not lexically.
+		{"v1.0.0-alpha.2", "v1.0.0-alpha.10", -1},
+		// Alphanumeric identifiers compare lexically and always outrank numeric ones.
+		{"v1.0.0-alpha.1", "v1.0.0-alpha.beta", -1},
+		// A larger set of prerelease fields outranks a smaller set when all
+		// shared fields are equal.
+		{"v1.0.0-alpha", "v1.0.0-alpha.1", -1},
+
+		// Build metadata must not affect precedence.
+		{"v1.0.0+build.1", "v1.0.0+build.2", 0},
+	}
+	for _, tt := range tests {
+		a, b := mustParse(tt.a), mustParse(tt.b)
+		if got := a.Compare(b); got != tt.want {
+			t.Errorf("%s.Compare(%s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+// Package modresolve resolves module versions against a Go module proxy
+// (https://go.dev/ref/mod#goproxy-protocol), honoring retractions and the
+// Go toolchain's anti-downgrade rule so callers can pick a version the
+// same way `go get` would.
+package modresolve
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// DefaultProxy is the public Go module proxy, used when callers pass an
+// empty proxy argument.
+const DefaultProxy = "https://proxy.golang.org"
+
+// Info mirrors the JSON served at <proxy>/<module>/@v/<version>.info.
+type Info struct {
+	Version string
+	Time    time.Time
+}
+
+// ResolveLatest returns the highest non-retracted version of modulePath
+// available from proxy. Prerelease versions are only considered if every
+// available version is a prerelease.
+func ResolveLatest(modulePath, proxy string) (string, error) {
+	versions, err := availableVersions(modulePath, proxy)
+	if err != nil {
+		return "", err
+	}
+	if best, ok := highestStable(versions); ok {
+		return best, nil
+	}
+	if best, ok := highestAny(versions); ok {
+		return best, nil
+	}
+	return "", fmt.Errorf("modresolve: no versions available for %s", modulePath)
+}
+
+// ResolvePatch returns the highest non-retracted version of modulePath
+// that shares currentVersion's major.minor, applying the Go toolchain's
+// anti-downgrade rule: a candidate only replaces currentVersion if it
+// outranks it by semver precedence (so a prerelease currentVersion that
+// ranks above every candidate is kept), and a pseudo-version
+// currentVersion whose commit time is later than the candidate's
+// published time is kept even if the candidate outranks it.
+func ResolvePatch(modulePath, currentVersion, proxy string) (string, error) {
+	if !semver.IsValid(currentVersion) {
+		return "", fmt.Errorf("modresolve: invalid current version %q", currentVersion)
+	}
+	versions, err := availableVersions(modulePath, proxy)
+	if err != nil {
+		return "", err
+	}
+
+	prefix := semver.MajorMinor(currentVersion)
+	best := currentVersion
+	for _, v := range versions {
+		if semver.MajorMinor(v) == prefix && semver.Compare(v, best) > 0 {
+			best = v
+		}
+	}
+	if best != currentVersion && module.IsPseudoVersion(currentVersion) {
+		if curTime, err := module.PseudoVersionTime(currentVersion); err == nil {
+			if escaped, err := module.EscapePath(modulePath); err == nil {
+				info, err := fetchInfo(withDefaultProxy(proxy), escaped, best)
+				if err == nil && curTime.After(info.Time) {
+					return currentVersion, nil
+				}
+			}
+		}
+	}
+	return best, nil
+}
+
+// RetractedVersions returns the retracted versions of modulePath, as
+// published in the retract directives of the go.mod of its highest
+// available version, keyed by version with the retraction rationale (or
+// "" if none was given) as the value.
+func RetractedVersions(modulePath, proxy string) (map[string]string, error) {
+	versions, err := listVersions(modulePath, proxy)
+	if err != nil {
+		return nil, err
+	}
+	latest, ok := highestAny(versions)
+	if !ok {
+		return nil, nil
+	}
+	return retractionsAt(modulePath, proxy, latest, versions)
+}
+
+// availableVersions returns the versions of modulePath published on proxy,
+// excluding any retracted by the latest version's go.mod.
+func availableVersions(modulePath, proxy string) ([]string, error) {
+	versions, err := listVersions(modulePath, proxy)
+	if err != nil {
+		return nil, err
+	}
+	latest, ok := highestAny(versions)
+	if !ok {
+		return nil, nil
+	}
+	retracted, err := retractionsAt(modulePath, proxy, latest, versions)
+	if err != nil {
+		return nil, err
+	}
+
+	var kept []string
+	for _, v := range versions {
+		if _, bad := retracted[v]; !bad {
+			kept = append(kept, v)
+		}
+	}
+	return kept, nil
+}
+
+// listVersions returns every version of modulePath listed by the
+// @v/list endpoint that also has a fetchable @v/<v>.info file.
+func listVersions(modulePath, proxy string) ([]string, error) {
+	escaped, err := module.EscapePath(modulePath)
+	if err != nil {
+		return nil, fmt.Errorf("modresolve: %w", err)
+	}
+	proxy = withDefaultProxy(proxy)
+
+	body, err := get(proxy, escaped, "@v/list")
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, line := range strings.Split(strings.TrimSpace(string(body)), "\n") {
+		v := strings.TrimSpace(line)
+		if v == "" || !semver.IsValid(v) {
+			continue
+		}
+		if _, err := fetchInfo(proxy, escaped, v); err != nil {
+			continue // listed but not fetchable (e.g. withdrawn)
+		}
+		versions = append(versions, v)
+	}
+	return versions, nil
+}
+
+// retractionsAt fetches the go.mod published at version and returns the
+// subset of versions that its retract directives cover.
+func retractionsAt(modulePath, proxy, version string, versions []string) (map[string]string, error) {
+	escaped, err := module.EscapePath(modulePath)
+	if err != nil {
+		return nil, fmt.Errorf("modresolve: %w", err)
+	}
+	proxy = withDefaultProxy(proxy)
+
+	data, err := get(proxy, escaped, "@v/"+version+".mod")
+	if err != nil {
+		return nil, err
+	}
+	f, err := modfile.Parse(modulePath+"@"+version+"/go.mod", data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("modresolve: parsing go.mod for %s@%s: %w", modulePath, version, err)
+	}
+
+	retracted := make(map[string]string)
+	for _, r := range f.Retract {
+		for _, v := range versions {
+			if semver.Compare(v, r.Low) >= 0 && semver.Compare(v, r.High) <= 0 {
+				retracted[v] = r.Rationale
+			}
+		}
+	}
+	return retracted, nil
+}
+
+func highestStable(versions []string) (string, bool) {
+	var best string
+	for _, v := range versions {
+		if semver.Prerelease(v) != "" {
+			continue
+		}
+		if best == "" || semver.Compare(v, best) > 0 {
+			best = v
+		}
+	}
+	return best, best != ""
+}
+
+func highestAny(versions []string) (string, bool) {
+	var best string
+	for _, v := range versions {
+		if best == "" || semver.Compare(v, best) > 0 {
+			best = v
+		}
+	}
+	return best, best != ""
+}
+
+func fetchInfo(proxy, escapedModulePath, version string) (Info, error) {
+	body, err := get(proxy, escapedModulePath, "@v/"+version+".info")
+	if err != nil {
+		return Info{}, err
+	}
+	var i Info
+	if err := json.Unmarshal(body, &i); err != nil {
+		return Info{}, fmt.Errorf("modresolve: parsing info for %s: %w", version, err)
+	}
+	return i, nil
+}
+
+func get(proxy, escapedModulePath, suffix string) ([]byte, error) {
+	u := strings.TrimSuffix(proxy, "/") + "/" + escapedModulePath + "/" + suffix
+	resp, err := http.Get(u)
+	if err != nil {
+		return nil, fmt.Errorf("modresolve: fetching %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("modresolve: fetching %s: %s", u, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func withDefaultProxy(proxy string) string {
+	if proxy == "" {
+		return DefaultProxy
+	}
+	return proxy
+}
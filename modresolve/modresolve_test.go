@@ -0,0 +1,75 @@
+package modresolve_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/xwinata/wstest/modresolve"
+)
+
+// newTestProxy serves the recorded module proxy responses under
+// testdata/proxy, matching the goproxy protocol's <module>/@v/... layout.
+func newTestProxy(t *testing.T) string {
+	t.Helper()
+	srv := httptest.NewServer(http.FileServer(http.Dir("testdata/proxy")))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestResolveLatestSkipsRetracted(t *testing.T) {
+	proxy := newTestProxy(t)
+
+	got, err := modresolve.ResolveLatest("github.com/xwinata/wstest/mathutils", proxy)
+	if err != nil {
+		t.Fatalf("ResolveLatest: %v", err)
+	}
+	// v1.0.2 is retracted in the fixture, so v1.0.1 is the latest usable
+	// version even though v1.0.2 sorts higher.
+	if want := "v1.0.1"; got != want {
+		t.Errorf("ResolveLatest() = %q, want %q", got, want)
+	}
+}
+
+func TestResolvePatchSkipsRetracted(t *testing.T) {
+	proxy := newTestProxy(t)
+
+	got, err := modresolve.ResolvePatch("github.com/xwinata/wstest/mathutils", "v1.0.0", proxy)
+	if err != nil {
+		t.Fatalf("ResolvePatch: %v", err)
+	}
+	if want := "v1.0.1"; got != want {
+		t.Errorf("ResolvePatch() = %q, want %q", got, want)
+	}
+}
+
+func TestResolvePatchKeepsNewerCurrent(t *testing.T) {
+	proxy := newTestProxy(t)
+
+	// v1.0.1 is already the highest non-retracted candidate, so it must
+	// be returned unchanged even though it isn't the literal max version
+	// in the list (v1.0.2, which is retracted).
+	got, err := modresolve.ResolvePatch("github.com/xwinata/wstest/mathutils", "v1.0.1", proxy)
+	if err != nil {
+		t.Fatalf("ResolvePatch: %v", err)
+	}
+	if want := "v1.0.1"; got != want {
+		t.Errorf("ResolvePatch() = %q, want %q", got, want)
+	}
+}
+
+func TestRetractedVersions(t *testing.T) {
+	proxy := newTestProxy(t)
+
+	retracted, err := modresolve.RetractedVersions("github.com/xwinata/wstest/mathutils", proxy)
+	if err != nil {
+		t.Fatalf("RetractedVersions: %v", err)
+	}
+	reason, ok := retracted["v1.0.2"]
+	if !ok {
+		t.Fatalf("RetractedVersions() = %v, want v1.0.2 present", retracted)
+	}
+	if want := "performance regression under heavy load"; reason != want {
+		t.Errorf("retraction reason = %q, want %q", reason, want)
+	}
+}
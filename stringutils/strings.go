@@ -2,9 +2,9 @@ package stringutils
 
 import "fmt"
 
-// HelloWorld returns a customizable greeting message
-func HelloWorld(name, greeting string) string {
-	return fmt.Sprintf("%s, %s!", greeting, name)
+// HelloWorld returns a greeting message
+func HelloWorld(name string) string {
+	return fmt.Sprintf("Hello, %s!", name)
 }
 
 // Reverse returns the reversed string
@@ -15,8 +15,3 @@ func Reverse(s string) string {
 	}
 	return string(runes)
 }
-
-// Version returns the module version
-func Version() string {
-	return "v2.0.0"
-}
\ No newline at end of file